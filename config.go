@@ -7,52 +7,84 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // supported otel exporters
 const (
-	fileExporter    = "file"
-	consoleExporter = "console"
-	otlpExporter    = "otlp"
+	fileExporter     = "file"
+	consoleExporter  = "console"
+	otlpExporter     = "otlp"
+	otlpHttpExporter = "otlphttp"
 )
 
 // Name of the trace parent envvar
 const traceParent = "TRACEPARENT"
 
-var (
-	tp          *sdkTrace.TracerProvider
-	serviceName string
-)
+// holds the package level state for the providers this package has
+// registered as global OTel defaults. A single struct is used instead of one
+// global per signal so that the tracing and metrics providers can be
+// initialized, inspected, and shut down independently of each other.
+type otelGlobals struct {
+	tp *sdkTrace.TracerProvider
+	mp *sdkmetric.MeterProvider
+	lp *sdklog.LoggerProvider
+}
+
+var global otelGlobals
 
-// initializes the otel configuration for the cli.
-func OtelInit(startCtx context.Context, resourceConf ResourceConfig, exporterConfig SpanExporterConfig) error {
+// initializes the otel configuration for the cli and returns a closure that
+// flushes and shuts down the TracerProvider it created. Unlike OtelEnd, the
+// returned closure is bound to this specific TracerProvider, so separate
+// calls to OtelInit (e.g. one exporting to stdout in tests, one to an OTLP
+// collector in prod) can be shut down independently and in a deterministic
+// order.
+func OtelInit(startCtx context.Context, resourceConf ResourceConfig, exporterConfig SpanExporterConfig, samplerConf SamplerConfig, batchConf BatchConfig) (func(context.Context) error, error) {
 	resource, err := resourceConf.newResource(startCtx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	exp, err := exporterConfig.newSpanExporter(startCtx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	tp = sdkTrace.NewTracerProvider(
-		sdkTrace.WithBatcher(exp),
+	tpOptions := []sdkTrace.TracerProviderOption{
+		sdkTrace.WithBatcher(exp, batchConf.getOptions()...),
 		sdkTrace.WithResource(resource),
-	)
+	}
+	if !samplerConf.isZero() {
+		tpOptions = append(tpOptions, sdkTrace.WithSampler(samplerConf.newSampler()))
+	}
+
+	tp := sdkTrace.NewTracerProvider(tpOptions...)
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
-	return nil
+	global.tp = tp
+
+	return func(shutdownCtx context.Context) error {
+		if err := tp.ForceFlush(shutdownCtx); err != nil {
+			return err
+		}
+		return tp.Shutdown(shutdownCtx)
+	}, nil
 }
 
-// flush and shutdown the global TracerProvider
+// OtelEnd flushes and shuts down the TracerProvider created by the most
+// recent call to OtelInit.
+//
+// Deprecated: use the shutdown closure returned by OtelInit instead. Each
+// call to OtelInit replaces the package level TracerProvider that OtelEnd
+// acts on, so OtelEnd only ever affects the most recently initialized one.
 func OtelEnd(endCtx context.Context) error {
-	if err := tp.ForceFlush(endCtx); err != nil {
+	if err := global.tp.ForceFlush(endCtx); err != nil {
 		return err
 	}
-	return tp.Shutdown(endCtx)
+	return global.tp.Shutdown(endCtx)
 }
 
 // GetTraceParentEnv returns the traceparent from ctx as an envvar
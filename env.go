@@ -0,0 +1,159 @@
+package otelutils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the OTEL_EXPORTER_OTLP_* environment variables honored by
+// *SpanExporterConfigs that have FromEnv set.
+const (
+	envEndpoint          = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envTracesEndpoint    = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	envHeaders           = "OTEL_EXPORTER_OTLP_HEADERS"
+	envTracesHeaders     = "OTEL_EXPORTER_OTLP_TRACES_HEADERS"
+	envCompression       = "OTEL_EXPORTER_OTLP_COMPRESSION"
+	envTimeout           = "OTEL_EXPORTER_OTLP_TIMEOUT"
+	envCertificate       = "OTEL_EXPORTER_OTLP_CERTIFICATE"
+	envClientCertificate = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
+	envClientKey         = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
+	envInsecure          = "OTEL_EXPORTER_OTLP_INSECURE"
+)
+
+// envSpanExporterConfig holds OTLP exporter settings read from the standard
+// OTEL_EXPORTER_OTLP_* environment variables. It is resolved once per
+// *SpanExporterConfig and used to fill in whichever fields were left at
+// their zero value.
+type envSpanExporterConfig struct {
+	endpoint         string
+	endpointInsecure bool
+	hasScheme        bool
+	headers          map[string]string
+	compression      string
+	timeout          time.Duration
+	tlsConfig        *tls.Config
+	insecure         bool
+	hasInsecure      bool
+}
+
+// newEnvSpanExporterConfig reads the OTEL_EXPORTER_OTLP_* environment
+// variables into an envSpanExporterConfig. The OTEL_EXPORTER_OTLP_TRACES_*
+// variables take precedence over their general OTEL_EXPORTER_OTLP_*
+// counterpart.
+func newEnvSpanExporterConfig() (envSpanExporterConfig, error) {
+	var c envSpanExporterConfig
+
+	endpoint := os.Getenv(envTracesEndpoint)
+	if endpoint == "" {
+		endpoint = os.Getenv(envEndpoint)
+	}
+	c.endpoint, c.endpointInsecure, c.hasScheme = normalizeOtlpEndpoint(endpoint)
+
+	headers := os.Getenv(envTracesHeaders)
+	if headers == "" {
+		headers = os.Getenv(envHeaders)
+	}
+	c.headers = parseOtlpHeaders(headers)
+
+	c.compression = os.Getenv(envCompression)
+
+	if timeout := os.Getenv(envTimeout); timeout != "" {
+		ms, err := strconv.Atoi(timeout)
+		if err != nil {
+			return c, fmt.Errorf("otelutils: invalid %s: %w", envTimeout, err)
+		}
+		c.timeout = time.Duration(ms) * time.Millisecond
+	}
+
+	if insecure := os.Getenv(envInsecure); insecure != "" {
+		c.insecure = strings.EqualFold(insecure, "true")
+		c.hasInsecure = true
+	}
+
+	certFile := os.Getenv(envCertificate)
+	clientCertFile := os.Getenv(envClientCertificate)
+	clientKeyFile := os.Getenv(envClientKey)
+	if certFile != "" || (clientCertFile != "" && clientKeyFile != "") {
+		tlsConfig, err := loadOtlpTLSConfig(certFile, clientCertFile, clientKeyFile)
+		if err != nil {
+			return c, err
+		}
+		c.tlsConfig = tlsConfig
+	}
+
+	return c, nil
+}
+
+// parseOtlpHeaders parses a header list of the form `key1=value1,key2=value2`
+// as used by OTEL_EXPORTER_OTLP_HEADERS and OTEL_EXPORTER_OTLP_TRACES_HEADERS.
+// Values are URL decoded.
+func parseOtlpHeaders(s string) map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			headers[key] = decoded
+		} else {
+			headers[key] = strings.TrimSpace(value)
+		}
+	}
+	return headers
+}
+
+// normalizeOtlpEndpoint strips a leading http:// or https:// scheme from
+// endpoint, as accepted by OTEL_EXPORTER_OTLP_ENDPOINT and friends, and
+// reports whether a scheme was present and, if so, whether it implies an
+// insecure (non-TLS) connection.
+func normalizeOtlpEndpoint(endpoint string) (normalized string, insecure bool, hasScheme bool) {
+	switch {
+	case strings.HasPrefix(endpoint, "http://"):
+		return strings.TrimPrefix(endpoint, "http://"), true, true
+	case strings.HasPrefix(endpoint, "https://"):
+		return strings.TrimPrefix(endpoint, "https://"), false, true
+	default:
+		return endpoint, false, false
+	}
+}
+
+// loadOtlpTLSConfig builds a tls.Config from PEM encoded certificate files,
+// as referenced by OTEL_EXPORTER_OTLP_CERTIFICATE,
+// OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE, and OTEL_EXPORTER_OTLP_CLIENT_KEY.
+func loadOtlpTLSConfig(certFile, clientCertFile, clientKeyFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if certFile != "" {
+		pem, err := os.ReadFile(certFile)
+		if err != nil {
+			return nil, fmt.Errorf("otelutils: failed to read %s: %w", envCertificate, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("otelutils: failed to parse certificate from %s", certFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCertFile != "" && clientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("otelutils: failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,81 @@
+package otelutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOtlpHeaders(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want map[string]string
+	}{
+		"empty": {
+			in:   "",
+			want: map[string]string{},
+		},
+		"single pair": {
+			in:   "api-key=secret",
+			want: map[string]string{"api-key": "secret"},
+		},
+		"multiple pairs with spaces": {
+			in:   "k1=v1, k2=v2",
+			want: map[string]string{"k1": "v1", "k2": "v2"},
+		},
+		"url encoded value": {
+			in:   "authorization=Bearer%20abc123",
+			want: map[string]string{"authorization": "Bearer abc123"},
+		},
+		"pair missing equals is skipped": {
+			in:   "k1=v1,notapair,k2=v2",
+			want: map[string]string{"k1": "v1", "k2": "v2"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := parseOtlpHeaders(tt.in)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseOtlpHeaders(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeOtlpEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		in             string
+		wantNormalized string
+		wantInsecure   bool
+		wantHasScheme  bool
+	}{
+		"no scheme": {
+			in:             "collector:4317",
+			wantNormalized: "collector:4317",
+			wantInsecure:   false,
+			wantHasScheme:  false,
+		},
+		"http scheme": {
+			in:             "http://collector:4318",
+			wantNormalized: "collector:4318",
+			wantInsecure:   true,
+			wantHasScheme:  true,
+		},
+		"https scheme": {
+			in:             "https://collector:4318",
+			wantNormalized: "collector:4318",
+			wantInsecure:   false,
+			wantHasScheme:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotNormalized, gotInsecure, gotHasScheme := normalizeOtlpEndpoint(tt.in)
+			if gotNormalized != tt.wantNormalized || gotInsecure != tt.wantInsecure || gotHasScheme != tt.wantHasScheme {
+				t.Errorf("normalizeOtlpEndpoint(%q) = (%q, %v, %v), want (%q, %v, %v)",
+					tt.in, gotNormalized, gotInsecure, gotHasScheme, tt.wantNormalized, tt.wantInsecure, tt.wantHasScheme)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package otelutils
+
+import (
+	"regexp"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+)
+
+// partialSuccessPattern matches the rejected item count embedded in the
+// error message the OTLP exporters report via otel.Handle when a collector
+// returns a partial success response, e.g. "... (2 spans rejected)" or
+// "... (2 metric data points rejected)" - the rejected kind is not always a
+// single word. The concrete error type that carries this information is
+// defined in an internal package of go.opentelemetry.io/otel and cannot be
+// imported here, so the rejected count is recovered from the message text
+// instead.
+var partialSuccessPattern = regexp.MustCompile(`\((\d+)\s+[^)]+?\s+rejected\)`)
+
+// SetErrorHandler installs a global otel.ErrorHandler that recognizes OTLP
+// partial success responses - raised when a collector accepts some, but not
+// all, of an exported batch - and dispatches them to onPartialSuccess with
+// the number of items rejected and the error message describing why. Every
+// other error is passed to whichever otel.ErrorHandler was registered before
+// this call. Without this, partial success responses are silently logged and
+// dropped by otel's default handling and applications have no way to know
+// the collector rejected spans.
+func SetErrorHandler(onPartialSuccess func(rejected int64, message string)) {
+	previous := otel.GetErrorHandler()
+
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		if onPartialSuccess != nil {
+			if rejected, ok := parsePartialSuccess(err.Error()); ok {
+				onPartialSuccess(rejected, err.Error())
+				return
+			}
+		}
+		previous.Handle(err)
+	}))
+}
+
+// parsePartialSuccess reports the rejected item count embedded in message if
+// message looks like an OTLP partial success error, and false otherwise.
+func parsePartialSuccess(message string) (rejected int64, ok bool) {
+	matches := partialSuccessPattern.FindStringSubmatch(message)
+	if matches == nil {
+		return 0, false
+	}
+	rejected, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return rejected, true
+}
@@ -0,0 +1,43 @@
+package otelutils
+
+import "testing"
+
+func TestParsePartialSuccess(t *testing.T) {
+	tests := map[string]struct {
+		message      string
+		wantRejected int64
+		wantOk       bool
+	}{
+		"not a partial success": {
+			message: "connection refused",
+			wantOk:  false,
+		},
+		"spans rejected": {
+			message:      "OTLP partial success (2 spans rejected)",
+			wantRejected: 2,
+			wantOk:       true,
+		},
+		"logs rejected": {
+			message:      "OTLP partial success (1 logs rejected)",
+			wantRejected: 1,
+			wantOk:       true,
+		},
+		"multi-word kind": {
+			message:      "OTLP partial success (3 metric data points rejected)",
+			wantRejected: 3,
+			wantOk:       true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotRejected, gotOk := parsePartialSuccess(tt.message)
+			if gotOk != tt.wantOk {
+				t.Fatalf("parsePartialSuccess(%q) ok = %v, want %v", tt.message, gotOk, tt.wantOk)
+			}
+			if gotOk && gotRejected != tt.wantRejected {
+				t.Errorf("parsePartialSuccess(%q) rejected = %d, want %d", tt.message, gotRejected, tt.wantRejected)
+			}
+		})
+	}
+}
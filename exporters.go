@@ -2,11 +2,15 @@ package otelutils
 
 import (
 	"context"
+	"crypto/tls"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"time"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
@@ -116,6 +120,50 @@ type OtlpGrpcSpanExporterConfig struct {
 	// `go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc` sets
 	// the default timeout to 10 seconds.
 	Timeout time.Duration
+	// If true, any of Compressor, Endpoint, Headers, Insecure,
+	// TlsCredentials, and Timeout left at their zero value will be filled in
+	// from the standard
+	// OTEL_EXPORTER_OTLP_* environment variables (with
+	// OTEL_EXPORTER_OTLP_TRACES_* taking precedence over the general
+	// variable) before the exporter is created. Explicit field values always
+	// take precedence over the environment.
+	FromEnv bool
+}
+
+// withEnv returns a copy of o with any zero valued fields eligible for
+// environment configuration filled in from the OTEL_EXPORTER_OTLP_*
+// environment variables.
+func (o OtlpGrpcSpanExporterConfig) withEnv() (OtlpGrpcSpanExporterConfig, error) {
+	envConf, err := newEnvSpanExporterConfig()
+	if err != nil {
+		return o, err
+	}
+
+	if o.Endpoint == "" {
+		o.Endpoint = envConf.endpoint
+	}
+	if len(o.Headers) == 0 {
+		o.Headers = envConf.headers
+	}
+	if o.Compressor == "" {
+		o.Compressor = envConf.compression
+	}
+	if o.Timeout == 0 {
+		o.Timeout = envConf.timeout
+	}
+	if o.TlsCredentials == nil && envConf.tlsConfig != nil {
+		creds := credentials.NewTLS(envConf.tlsConfig)
+		o.TlsCredentials = &creds
+	}
+	if !o.Insecure {
+		if envConf.hasInsecure {
+			o.Insecure = envConf.insecure
+		} else if envConf.hasScheme {
+			o.Insecure = envConf.endpointInsecure
+		}
+	}
+
+	return o, nil
 }
 
 // Returns a list of otlptracegrpc.Option based on the values of the fields in
@@ -163,6 +211,13 @@ func (o OtlpGrpcSpanExporterConfig) getOptions() []otlptracegrpc.Option {
 }
 
 func (o OtlpGrpcSpanExporterConfig) newSpanExporter(ctx context.Context) (trace.SpanExporter, error) {
+	if o.FromEnv {
+		var err error
+		o, err = o.withEnv()
+		if err != nil {
+			return nil, err
+		}
+	}
 	return otlptracegrpc.New(ctx, o.getOptions()...)
 }
 
@@ -203,3 +258,175 @@ func RetryMaxElapsedTime(interval time.Duration) RetryOption {
 		rc.MaxElapsedTime = interval
 	}
 }
+
+// OtlpHttpSpanExporterConfig implements SpanExporterConfig. It is used to
+// create an otel exporter that sends ended spans to an OTLP collector using
+// HTTP.
+type OtlpHttpSpanExporterConfig struct {
+	// Set the target endpoint the exporter will connect to. If unset
+	// `go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp` uses
+	// `localhost:4318` as the default.
+	Endpoint string
+	// Set the path to be used in the URL for exports. If unset
+	// `go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp` uses
+	// `/v1/traces` as the default.
+	URLPath string
+	// The headers that will be used with each HTTP request.
+	Headers map[string]string
+	// If true, client transport security for the exporter's HTTP connection is
+	// disabled. If false, client security is required.
+	Insecure bool
+	// TLS config to use when talking to the server. This option has no effect
+	// if Insecure is true.
+	TLSClientConfig *tls.Config
+	// Sets the compression strategy the exporter will use to compress the
+	// HTTP body. If unset, no compression will be used.
+	Compression otlptracehttp.Compression
+	// Sets the proxy function the HTTP client will use to determine the
+	// proxy to use for a request. If unset the HTTP client will use
+	// http.ProxyFromEnvironment.
+	Proxy func(*http.Request) (*url.URL, error)
+	// Sets the max amount of time a client will attempt to export a batch of
+	// spans. This takes precedence over any retry settings defined in
+	// RetryOptions. Once the time limit is reached the export is abandoned
+	// and the batch of spans is dropped. If unset,
+	// `go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp` sets
+	// the default timeout to 10 seconds.
+	Timeout time.Duration
+	// Sets the retry policy for transient retryable errors that may be
+	// returned by the target endpoint when exporting a batch of spans. If
+	// empty, the default retry policy will be used. Options in the list are
+	// applied to the default retry policy.
+	RetryOptions []HttpRetryOption
+	// If true, any of Endpoint, Headers, Insecure, TLSClientConfig,
+	// Compression (gzip only), and Timeout left at their zero value will be
+	// filled in from the standard
+	// OTEL_EXPORTER_OTLP_* environment variables (with
+	// OTEL_EXPORTER_OTLP_TRACES_* taking precedence over the general
+	// variable) before the exporter is created. Explicit field values always
+	// take precedence over the environment.
+	FromEnv bool
+}
+
+// withEnv returns a copy of o with any zero valued fields eligible for
+// environment configuration filled in from the OTEL_EXPORTER_OTLP_*
+// environment variables.
+func (o OtlpHttpSpanExporterConfig) withEnv() (OtlpHttpSpanExporterConfig, error) {
+	envConf, err := newEnvSpanExporterConfig()
+	if err != nil {
+		return o, err
+	}
+
+	if o.Endpoint == "" {
+		o.Endpoint = envConf.endpoint
+	}
+	if len(o.Headers) == 0 {
+		o.Headers = envConf.headers
+	}
+	if o.Compression == otlptracehttp.NoCompression && envConf.compression == "gzip" {
+		o.Compression = otlptracehttp.GzipCompression
+	}
+	if o.Timeout == 0 {
+		o.Timeout = envConf.timeout
+	}
+	if o.TLSClientConfig == nil {
+		o.TLSClientConfig = envConf.tlsConfig
+	}
+	if !o.Insecure {
+		if envConf.hasInsecure {
+			o.Insecure = envConf.insecure
+		} else if envConf.hasScheme {
+			o.Insecure = envConf.endpointInsecure
+		}
+	}
+
+	return o, nil
+}
+
+// Returns a list of otlptracehttp.Option based on the values of the fields in
+// o.
+func (o OtlpHttpSpanExporterConfig) getOptions() []otlptracehttp.Option {
+	var options []otlptracehttp.Option
+	if o.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpoint(o.Endpoint))
+	}
+	if o.URLPath != "" {
+		options = append(options, otlptracehttp.WithURLPath(o.URLPath))
+	}
+	if len(o.Headers) > 0 {
+		options = append(options, otlptracehttp.WithHeaders(o.Headers))
+	}
+	if o.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if o.TLSClientConfig != nil {
+		options = append(options, otlptracehttp.WithTLSClientConfig(o.TLSClientConfig))
+	}
+	if o.Compression != 0 {
+		options = append(options, otlptracehttp.WithCompression(o.Compression))
+	}
+	if o.Proxy != nil {
+		options = append(options, otlptracehttp.WithProxy(o.Proxy))
+	}
+	if o.Timeout != 0 {
+		options = append(options, otlptracehttp.WithTimeout(o.Timeout))
+	}
+	if len(o.RetryOptions) > 0 {
+		retryConfig := otlptracehttp.RetryConfig{}
+		for _, option := range o.RetryOptions {
+			option(&retryConfig)
+		}
+		options = append(options, otlptracehttp.WithRetry(retryConfig))
+	}
+	return options
+}
+
+func (o OtlpHttpSpanExporterConfig) newSpanExporter(ctx context.Context) (trace.SpanExporter, error) {
+	if o.FromEnv {
+		var err error
+		o, err = o.withEnv()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return otlptracehttp.New(ctx, o.getOptions()...)
+}
+
+// HttpRetryOption are used to configure an otlphttp exporters RetryConfig
+type HttpRetryOption func(*otlptracehttp.RetryConfig)
+
+// Returns a HttpRetryOption that sets retry.Config.Enabled. Enabled indicates
+// whether or not to retry sending batches in case of an export failure.
+func HttpRetryEnabled(enabled bool) HttpRetryOption {
+	return func(rc *otlptracehttp.RetryConfig) {
+		rc.Enabled = enabled
+	}
+}
+
+// Returns a HttpRetryOption that sets the of retry.Config.InitialInterval.
+// InitialInterval sets the time to wait after the first failure before
+// retrying.
+func HttpRetryInitialInterval(interval time.Duration) HttpRetryOption {
+	return func(rc *otlptracehttp.RetryConfig) {
+		rc.InitialInterval = interval
+	}
+}
+
+// Returns a HttpRetryOption that sets retry.Config.MaxInterval. MaxInterval is
+// upper bound on the backoff interval. Once this value is reached, the delay
+// between consecutive retries will always be `MaxInterval`.
+func HttpRetryMaxInterval(interval time.Duration) HttpRetryOption {
+	return func(rc *otlptracehttp.RetryConfig) {
+		rc.MaxInterval = interval
+	}
+}
+
+// Returns a HttpRetryOption that sets retry.Config.MaxElapsedTime.
+// MaxElapsedTime is the maximum amount of time (including retries) spent
+// trying to send a request/batch. Once this value is reached, the data is
+// discarded.
+func HttpRetryMaxElapsedTime(interval time.Duration) HttpRetryOption {
+	return func(rc *otlptracehttp.RetryConfig) {
+		rc.MaxElapsedTime = interval
+	}
+}
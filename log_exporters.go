@@ -0,0 +1,278 @@
+package otelutils
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Provides a common interface for *LogExporterConfigs
+type LogExporterConfig interface {
+	newLogExporter(context.Context) (sdklog.Exporter, error)
+}
+
+// OtlpGrpcLogExporterConfig implements LogExporterConfig. It is used to
+// create an otel exporter that sends emitted log records to an OTLP
+// collector using gRPC.
+type OtlpGrpcLogExporterConfig struct {
+	// a compressor for the gRPC client to use when sending requests. It is the
+	// responsibility of the caller to ensure that the compressor has been
+	// registered with google.golang.org/grpc/encoding. This has no effect if
+	// GrpcConn is provided.
+	Compressor string
+	// grpc.DialOptions that will be used when making a connection. This has no
+	// effect if GrpcConn is provided.
+	DialOptions []grpc.DialOption
+	// Set the target endpoint the exporter will connect to. If unset
+	// `go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc` uses
+	// `localhost:4317` as the default. This has no effect if GrpcConn is
+	// provided.
+	Endpoint string
+	// If set, GrpcConn will be the gRPC connection used for all communication.
+	// It is the callers responsibility to close the passed connection.
+	GrpcConn *grpc.ClientConn
+	// The headers that will be used with each gRPC request.
+	Headers map[string]string
+	// If true, client transport security for the exporter's gRPC connection is
+	// disabled. This has no effect if GrpcConn is provided.
+	Insecure bool
+	// Sets the minimum amount of time between connection attempts to the
+	// target endpoint. This has no effect if GrpcConn is provided.
+	ReconnectionPeriod time.Duration
+	// Sets the retry policy for transient retryable errors that may be
+	// returned by the target endpoint when exporting log records. If empty,
+	// the default retry policy will be used. Options in the list are applied
+	// to the default retry policy.
+	RetryOptions []LogRetryOption
+	// defines the default gRPC service config used. This option has no effect
+	// if GrpcConn is provided.
+	ServiceConfig string
+	// TLS Credentials used when talking to the server. This option has no
+	// effect if GrpcConn is provided.
+	TlsCredentials *credentials.TransportCredentials
+	// Sets the max amount of time a client will attempt to export log
+	// records. This takes precedence over any retry settings defined in
+	// RetryOptions. If unset,
+	// `go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc` sets
+	// the default timeout to 10 seconds.
+	Timeout time.Duration
+}
+
+// Returns a list of otlploggrpc.Option based on the values of the fields in
+// o.
+func (o OtlpGrpcLogExporterConfig) getOptions() []otlploggrpc.Option {
+	var options []otlploggrpc.Option
+	if o.Compressor != "" {
+		options = append(options, otlploggrpc.WithCompressor(o.Compressor))
+	}
+	if len(o.DialOptions) > 0 {
+		options = append(options, otlploggrpc.WithDialOption(o.DialOptions...))
+	}
+	if o.Endpoint != "" {
+		options = append(options, otlploggrpc.WithEndpoint(o.Endpoint))
+	}
+	if o.GrpcConn != nil {
+		options = append(options, otlploggrpc.WithGRPCConn(o.GrpcConn))
+	}
+	if len(o.Headers) > 0 {
+		options = append(options, otlploggrpc.WithHeaders(o.Headers))
+	}
+	if o.Insecure {
+		options = append(options, otlploggrpc.WithInsecure())
+	}
+	if o.ReconnectionPeriod != 0 {
+		options = append(options, otlploggrpc.WithReconnectionPeriod(o.ReconnectionPeriod))
+	}
+	if len(o.RetryOptions) > 0 {
+		retryConfig := otlploggrpc.RetryConfig{}
+		for _, option := range o.RetryOptions {
+			option(&retryConfig)
+		}
+		options = append(options, otlploggrpc.WithRetry(retryConfig))
+	}
+	if o.ServiceConfig != "" {
+		options = append(options, otlploggrpc.WithServiceConfig(o.ServiceConfig))
+	}
+	if o.TlsCredentials != nil {
+		options = append(options, otlploggrpc.WithTLSCredentials(*o.TlsCredentials))
+	}
+	if o.Timeout != 0 {
+		options = append(options, otlploggrpc.WithTimeout(o.Timeout))
+	}
+	return options
+}
+
+func (o OtlpGrpcLogExporterConfig) newLogExporter(ctx context.Context) (sdklog.Exporter, error) {
+	return otlploggrpc.New(ctx, o.getOptions()...)
+}
+
+// LogRetryOption are used to configure an otlploggrpc exporters RetryConfig
+type LogRetryOption func(*otlploggrpc.RetryConfig)
+
+// Returns a LogRetryOption that sets retry.Config.Enabled. Enabled indicates
+// whether or not to retry sending batches in case of an export failure.
+func LogRetryEnabled(enabled bool) LogRetryOption {
+	return func(rc *otlploggrpc.RetryConfig) {
+		rc.Enabled = enabled
+	}
+}
+
+// Returns a LogRetryOption that sets retry.Config.InitialInterval.
+// InitialInterval sets the time to wait after the first failure before
+// retrying.
+func LogRetryInitialInterval(interval time.Duration) LogRetryOption {
+	return func(rc *otlploggrpc.RetryConfig) {
+		rc.InitialInterval = interval
+	}
+}
+
+// Returns a LogRetryOption that sets retry.Config.MaxInterval. MaxInterval is
+// upper bound on the backoff interval. Once this value is reached, the delay
+// between consecutive retries will always be `MaxInterval`.
+func LogRetryMaxInterval(interval time.Duration) LogRetryOption {
+	return func(rc *otlploggrpc.RetryConfig) {
+		rc.MaxInterval = interval
+	}
+}
+
+// Returns a LogRetryOption that sets retry.Config.MaxElapsedTime.
+// MaxElapsedTime is the maximum amount of time (including retries) spent
+// trying to send a request/batch. Once this value is reached, the data is
+// discarded.
+func LogRetryMaxElapsedTime(interval time.Duration) LogRetryOption {
+	return func(rc *otlploggrpc.RetryConfig) {
+		rc.MaxElapsedTime = interval
+	}
+}
+
+// OtlpHttpLogExporterConfig implements LogExporterConfig. It is used to
+// create an otel exporter that sends emitted log records to an OTLP
+// collector using HTTP.
+type OtlpHttpLogExporterConfig struct {
+	// Set the target endpoint the exporter will connect to. If unset
+	// `go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp` uses
+	// `localhost:4318` as the default.
+	Endpoint string
+	// Set the path to be used in the URL for exports. If unset
+	// `go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp` uses
+	// `/v1/logs` as the default.
+	URLPath string
+	// The headers that will be used with each HTTP request.
+	Headers map[string]string
+	// If true, client transport security for the exporter's HTTP connection is
+	// disabled. If false, client security is required.
+	Insecure bool
+	// TLS config to use when talking to the server. This option has no effect
+	// if Insecure is true.
+	TLSClientConfig *tls.Config
+	// Sets the compression strategy the exporter will use to compress the
+	// HTTP body. If unset, no compression will be used.
+	Compression otlploghttp.Compression
+	// Sets the proxy function the HTTP client will use to determine the
+	// proxy to use for a request. If unset the HTTP client will use
+	// http.ProxyFromEnvironment.
+	Proxy func(*http.Request) (*url.URL, error)
+	// Sets the max amount of time a client will attempt to export log
+	// records. This takes precedence over any retry settings defined in
+	// RetryOptions. If unset,
+	// `go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp` sets the
+	// default timeout to 10 seconds.
+	Timeout time.Duration
+	// Sets the retry policy for transient retryable errors that may be
+	// returned by the target endpoint when exporting log records. If empty,
+	// the default retry policy will be used. Options in the list are applied
+	// to the default retry policy.
+	RetryOptions []LogHttpRetryOption
+}
+
+// Returns a list of otlploghttp.Option based on the values of the fields in
+// o.
+func (o OtlpHttpLogExporterConfig) getOptions() []otlploghttp.Option {
+	var options []otlploghttp.Option
+	if o.Endpoint != "" {
+		options = append(options, otlploghttp.WithEndpoint(o.Endpoint))
+	}
+	if o.URLPath != "" {
+		options = append(options, otlploghttp.WithURLPath(o.URLPath))
+	}
+	if len(o.Headers) > 0 {
+		options = append(options, otlploghttp.WithHeaders(o.Headers))
+	}
+	if o.Insecure {
+		options = append(options, otlploghttp.WithInsecure())
+	}
+	if o.TLSClientConfig != nil {
+		options = append(options, otlploghttp.WithTLSClientConfig(o.TLSClientConfig))
+	}
+	if o.Compression != 0 {
+		options = append(options, otlploghttp.WithCompression(o.Compression))
+	}
+	if o.Proxy != nil {
+		options = append(options, otlploghttp.WithProxy(o.Proxy))
+	}
+	if o.Timeout != 0 {
+		options = append(options, otlploghttp.WithTimeout(o.Timeout))
+	}
+	if len(o.RetryOptions) > 0 {
+		retryConfig := otlploghttp.RetryConfig{}
+		for _, option := range o.RetryOptions {
+			option(&retryConfig)
+		}
+		options = append(options, otlploghttp.WithRetry(retryConfig))
+	}
+	return options
+}
+
+func (o OtlpHttpLogExporterConfig) newLogExporter(ctx context.Context) (sdklog.Exporter, error) {
+	return otlploghttp.New(ctx, o.getOptions()...)
+}
+
+// LogHttpRetryOption are used to configure an otlploghttp exporters
+// RetryConfig
+type LogHttpRetryOption func(*otlploghttp.RetryConfig)
+
+// Returns a LogHttpRetryOption that sets retry.Config.Enabled. Enabled
+// indicates whether or not to retry sending batches in case of an export
+// failure.
+func LogHttpRetryEnabled(enabled bool) LogHttpRetryOption {
+	return func(rc *otlploghttp.RetryConfig) {
+		rc.Enabled = enabled
+	}
+}
+
+// Returns a LogHttpRetryOption that sets retry.Config.InitialInterval.
+// InitialInterval sets the time to wait after the first failure before
+// retrying.
+func LogHttpRetryInitialInterval(interval time.Duration) LogHttpRetryOption {
+	return func(rc *otlploghttp.RetryConfig) {
+		rc.InitialInterval = interval
+	}
+}
+
+// Returns a LogHttpRetryOption that sets retry.Config.MaxInterval.
+// MaxInterval is upper bound on the backoff interval. Once this value is
+// reached, the delay between consecutive retries will always be
+// `MaxInterval`.
+func LogHttpRetryMaxInterval(interval time.Duration) LogHttpRetryOption {
+	return func(rc *otlploghttp.RetryConfig) {
+		rc.MaxInterval = interval
+	}
+}
+
+// Returns a LogHttpRetryOption that sets retry.Config.MaxElapsedTime.
+// MaxElapsedTime is the maximum amount of time (including retries) spent
+// trying to send a request/batch. Once this value is reached, the data is
+// discarded.
+func LogHttpRetryMaxElapsedTime(interval time.Duration) LogHttpRetryOption {
+	return func(rc *otlploghttp.RetryConfig) {
+		rc.MaxElapsedTime = interval
+	}
+}
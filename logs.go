@@ -0,0 +1,80 @@
+package otelutils
+
+import (
+	"context"
+	"time"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	logGlobal "go.opentelemetry.io/otel/log/global"
+)
+
+// LogProcessorConfig is used to configure the sdklog.BatchProcessor that
+// LogsInit builds around the LogExporterConfig it is given.
+type LogProcessorConfig struct {
+	// Sets the maximum queue size used to buffer log records for delayed
+	// processing. If unset, `go.opentelemetry.io/otel/sdk/log` uses 2048 as
+	// the default. If ExportMaxBatchSize is greater than MaxQueueSize,
+	// MaxQueueSize will be set to ExportMaxBatchSize.
+	MaxQueueSize int
+	// Sets the maximum duration between batched exports. If unset,
+	// `go.opentelemetry.io/otel/sdk/log` uses 1 second as the default.
+	ExportInterval time.Duration
+	// Sets the maximum number of log records to include in a single export.
+	// If unset, `go.opentelemetry.io/otel/sdk/log` uses 512 as the default.
+	ExportMaxBatchSize int
+	// Sets the maximum amount of time a batch export is allowed to run
+	// before it is canceled. If unset,
+	// `go.opentelemetry.io/otel/sdk/log` uses 30 seconds as the default.
+	ExportTimeout time.Duration
+}
+
+// Returns a list of sdklog.BatchProcessorOption based on the values of the
+// fields in l.
+func (l LogProcessorConfig) getOptions() []sdklog.BatchProcessorOption {
+	var options []sdklog.BatchProcessorOption
+	if l.MaxQueueSize != 0 {
+		options = append(options, sdklog.WithMaxQueueSize(l.MaxQueueSize))
+	}
+	if l.ExportInterval != 0 {
+		options = append(options, sdklog.WithExportInterval(l.ExportInterval))
+	}
+	if l.ExportMaxBatchSize != 0 {
+		options = append(options, sdklog.WithExportMaxBatchSize(l.ExportMaxBatchSize))
+	}
+	if l.ExportTimeout != 0 {
+		options = append(options, sdklog.WithExportTimeout(l.ExportTimeout))
+	}
+	return options
+}
+
+// initializes the otel logs configuration for the cli.
+func LogsInit(startCtx context.Context, resourceConf ResourceConfig, exporterConfig LogExporterConfig, processorConfig LogProcessorConfig) error {
+	resource, err := resourceConf.newResource(startCtx)
+	if err != nil {
+		return err
+	}
+
+	exp, err := exporterConfig.newLogExporter(startCtx)
+	if err != nil {
+		return err
+	}
+
+	processor := sdklog.NewBatchProcessor(exp, processorConfig.getOptions()...)
+
+	global.lp = sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(processor),
+		sdklog.WithResource(resource),
+	)
+	logGlobal.SetLoggerProvider(global.lp)
+
+	return nil
+}
+
+// flush and shutdown the global LoggerProvider
+func LogsEnd(endCtx context.Context) error {
+	if err := global.lp.ForceFlush(endCtx); err != nil {
+		return err
+	}
+	return global.lp.Shutdown(endCtx)
+}
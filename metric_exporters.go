@@ -0,0 +1,320 @@
+package otelutils
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Provides a common interface for *MetricExporterConfigs
+type MetricExporterConfig interface {
+	newMetricExporter(context.Context) (sdkmetric.Exporter, error)
+}
+
+// ConsoleMetricExporterConfig implements MetricExporterConfig. It is used to
+// create an otel exporter that writes to a provided io.Writer. os.Stdout is
+// the default writer
+type ConsoleMetricExporterConfig struct {
+	// Sets the export stream format to use JSON.
+	PrettyPrint bool
+	// Sets the export stream to include timestamps.
+	Timestamps bool
+	// Sets the export destination stream.
+	Writer *io.Writer
+}
+
+// Returns a list of stdoutmetric.Option based on the values of the fields in
+// c. If c.Writer == nil, os.Stdout is passed to stdoutmetric.WithWriter
+func (c ConsoleMetricExporterConfig) getOptions() []stdoutmetric.Option {
+	var options []stdoutmetric.Option
+	if c.PrettyPrint {
+		options = append(options, stdoutmetric.WithPrettyPrint())
+	}
+	if !c.Timestamps {
+		options = append(options, stdoutmetric.WithoutTimestamps())
+	}
+	if c.Writer == nil {
+		options = append(options, stdoutmetric.WithWriter(os.Stdout))
+	} else {
+		options = append(options, stdoutmetric.WithWriter(*c.Writer))
+	}
+	return options
+}
+
+// newMetricExporter returns a stdoutmetric.Exporter. The console exporter
+// writes to the location specified by the Writer. This could be a file or
+// stdout/stderr.
+func (c ConsoleMetricExporterConfig) newMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	return stdoutmetric.New(c.getOptions()...)
+}
+
+// OtlpGrpcMetricExporterConfig implements MetricExporterConfig. It is used to
+// create an otel exporter that sends collected metrics to an OTLP collector
+// using gRPC.
+type OtlpGrpcMetricExporterConfig struct {
+	// a compressor for the gRPC client to use when sending requests. It is the
+	// responsibility of the caller to ensure that the compressor has been
+	// registered with google.golang.org/grpc/encoding. This has no effect if
+	// GrpcConn is provided.
+	Compressor string
+	// grpc.DialOptions that will be used when making a connection. This has no
+	// effect if GrpcConn is provided.
+	DialOptions []grpc.DialOption
+	// Set the target endpoint the exporter will connect to. If unset
+	// `go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc` uses
+	// `localhost:4317` as the default. This has no effect if GrpcConn is
+	// provided.
+	Endpoint string
+	// If set, GrpcConn will be the gRPC connection used for all communication.
+	// It is the callers responsibility to close the passed connection.
+	GrpcConn *grpc.ClientConn
+	// The headers that will be used with each gRPC request.
+	Headers map[string]string
+	// If true, client transport security for the exporter's gRPC connection is
+	// disabled. This has no effect if GrpcConn is provided.
+	Insecure bool
+	// Sets the minimum amount of time between connection attempts to the
+	// target endpoint. This has no effect if GrpcConn is provided.
+	ReconnectionPeriod time.Duration
+	// Sets the retry policy for transient retryable errors that may be
+	// returned by the target endpoint when exporting metrics. If empty, the
+	// default retry policy will be used. Options in the list are applied to
+	// the default retry policy.
+	RetryOptions []MetricRetryOption
+	// defines the default gRPC service config used. This option has no effect
+	// if GrpcConn is provided.
+	ServiceConfig string
+	// TLS Credentials used when talking to the server. This option has no
+	// effect if GrpcConn is provided.
+	TlsCredentials *credentials.TransportCredentials
+	// Sets the max amount of time a client will attempt to export metrics.
+	// This takes precedence over any retry settings defined in RetryOptions.
+	// If unset,
+	// `go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc` sets
+	// the default timeout to 10 seconds.
+	Timeout time.Duration
+}
+
+// Returns a list of otlpmetricgrpc.Option based on the values of the fields
+// in o.
+func (o OtlpGrpcMetricExporterConfig) getOptions() []otlpmetricgrpc.Option {
+	var options []otlpmetricgrpc.Option
+	if o.Compressor != "" {
+		options = append(options, otlpmetricgrpc.WithCompressor(o.Compressor))
+	}
+	if len(o.DialOptions) > 0 {
+		options = append(options, otlpmetricgrpc.WithDialOption(o.DialOptions...))
+	}
+	if o.Endpoint != "" {
+		options = append(options, otlpmetricgrpc.WithEndpoint(o.Endpoint))
+	}
+	if o.GrpcConn != nil {
+		options = append(options, otlpmetricgrpc.WithGRPCConn(o.GrpcConn))
+	}
+	if len(o.Headers) > 0 {
+		options = append(options, otlpmetricgrpc.WithHeaders(o.Headers))
+	}
+	if o.Insecure {
+		options = append(options, otlpmetricgrpc.WithInsecure())
+	}
+	if o.ReconnectionPeriod != 0 {
+		options = append(options, otlpmetricgrpc.WithReconnectionPeriod(o.ReconnectionPeriod))
+	}
+	if len(o.RetryOptions) > 0 {
+		retryConfig := otlpmetricgrpc.RetryConfig{}
+		for _, option := range o.RetryOptions {
+			option(&retryConfig)
+		}
+		options = append(options, otlpmetricgrpc.WithRetry(retryConfig))
+	}
+	if o.ServiceConfig != "" {
+		options = append(options, otlpmetricgrpc.WithServiceConfig(o.ServiceConfig))
+	}
+	if o.TlsCredentials != nil {
+		options = append(options, otlpmetricgrpc.WithTLSCredentials(*o.TlsCredentials))
+	}
+	if o.Timeout != 0 {
+		options = append(options, otlpmetricgrpc.WithTimeout(o.Timeout))
+	}
+	return options
+}
+
+func (o OtlpGrpcMetricExporterConfig) newMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	return otlpmetricgrpc.New(ctx, o.getOptions()...)
+}
+
+// MetricRetryOption are used to configure an otlpmetricgrpc exporters
+// RetryConfig
+type MetricRetryOption func(*otlpmetricgrpc.RetryConfig)
+
+// Returns a MetricRetryOption that sets retry.Config.Enabled. Enabled
+// indicates whether or not to retry sending batches in case of an export
+// failure.
+func MetricRetryEnabled(enabled bool) MetricRetryOption {
+	return func(rc *otlpmetricgrpc.RetryConfig) {
+		rc.Enabled = enabled
+	}
+}
+
+// Returns a MetricRetryOption that sets retry.Config.InitialInterval.
+// InitialInterval sets the time to wait after the first failure before
+// retrying.
+func MetricRetryInitialInterval(interval time.Duration) MetricRetryOption {
+	return func(rc *otlpmetricgrpc.RetryConfig) {
+		rc.InitialInterval = interval
+	}
+}
+
+// Returns a MetricRetryOption that sets retry.Config.MaxInterval. MaxInterval
+// is upper bound on the backoff interval. Once this value is reached, the
+// delay between consecutive retries will always be `MaxInterval`.
+func MetricRetryMaxInterval(interval time.Duration) MetricRetryOption {
+	return func(rc *otlpmetricgrpc.RetryConfig) {
+		rc.MaxInterval = interval
+	}
+}
+
+// Returns a MetricRetryOption that sets retry.Config.MaxElapsedTime.
+// MaxElapsedTime is the maximum amount of time (including retries) spent
+// trying to send a request/batch. Once this value is reached, the data is
+// discarded.
+func MetricRetryMaxElapsedTime(interval time.Duration) MetricRetryOption {
+	return func(rc *otlpmetricgrpc.RetryConfig) {
+		rc.MaxElapsedTime = interval
+	}
+}
+
+// OtlpHttpMetricExporterConfig implements MetricExporterConfig. It is used to
+// create an otel exporter that sends collected metrics to an OTLP collector
+// using HTTP.
+type OtlpHttpMetricExporterConfig struct {
+	// Set the target endpoint the exporter will connect to. If unset
+	// `go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp`
+	// uses `localhost:4318` as the default.
+	Endpoint string
+	// Set the path to be used in the URL for exports. If unset
+	// `go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp`
+	// uses `/v1/metrics` as the default.
+	URLPath string
+	// The headers that will be used with each HTTP request.
+	Headers map[string]string
+	// If true, client transport security for the exporter's HTTP connection is
+	// disabled. If false, client security is required.
+	Insecure bool
+	// TLS config to use when talking to the server. This option has no effect
+	// if Insecure is true.
+	TLSClientConfig *tls.Config
+	// Sets the compression strategy the exporter will use to compress the
+	// HTTP body. If unset, no compression will be used.
+	Compression otlpmetrichttp.Compression
+	// Sets the proxy function the HTTP client will use to determine the
+	// proxy to use for a request. If unset the HTTP client will use
+	// http.ProxyFromEnvironment.
+	Proxy func(*http.Request) (*url.URL, error)
+	// Sets the max amount of time a client will attempt to export metrics.
+	// This takes precedence over any retry settings defined in RetryOptions.
+	// If unset,
+	// `go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp`
+	// sets the default timeout to 10 seconds.
+	Timeout time.Duration
+	// Sets the retry policy for transient retryable errors that may be
+	// returned by the target endpoint when exporting metrics. If empty, the
+	// default retry policy will be used. Options in the list are applied to
+	// the default retry policy.
+	RetryOptions []MetricHttpRetryOption
+}
+
+// Returns a list of otlpmetrichttp.Option based on the values of the fields
+// in o.
+func (o OtlpHttpMetricExporterConfig) getOptions() []otlpmetrichttp.Option {
+	var options []otlpmetrichttp.Option
+	if o.Endpoint != "" {
+		options = append(options, otlpmetrichttp.WithEndpoint(o.Endpoint))
+	}
+	if o.URLPath != "" {
+		options = append(options, otlpmetrichttp.WithURLPath(o.URLPath))
+	}
+	if len(o.Headers) > 0 {
+		options = append(options, otlpmetrichttp.WithHeaders(o.Headers))
+	}
+	if o.Insecure {
+		options = append(options, otlpmetrichttp.WithInsecure())
+	}
+	if o.TLSClientConfig != nil {
+		options = append(options, otlpmetrichttp.WithTLSClientConfig(o.TLSClientConfig))
+	}
+	if o.Compression != 0 {
+		options = append(options, otlpmetrichttp.WithCompression(o.Compression))
+	}
+	if o.Proxy != nil {
+		options = append(options, otlpmetrichttp.WithProxy(o.Proxy))
+	}
+	if o.Timeout != 0 {
+		options = append(options, otlpmetrichttp.WithTimeout(o.Timeout))
+	}
+	if len(o.RetryOptions) > 0 {
+		retryConfig := otlpmetrichttp.RetryConfig{}
+		for _, option := range o.RetryOptions {
+			option(&retryConfig)
+		}
+		options = append(options, otlpmetrichttp.WithRetry(retryConfig))
+	}
+	return options
+}
+
+func (o OtlpHttpMetricExporterConfig) newMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	return otlpmetrichttp.New(ctx, o.getOptions()...)
+}
+
+// MetricHttpRetryOption are used to configure an otlpmetrichttp exporters
+// RetryConfig
+type MetricHttpRetryOption func(*otlpmetrichttp.RetryConfig)
+
+// Returns a MetricHttpRetryOption that sets retry.Config.Enabled. Enabled
+// indicates whether or not to retry sending batches in case of an export
+// failure.
+func MetricHttpRetryEnabled(enabled bool) MetricHttpRetryOption {
+	return func(rc *otlpmetrichttp.RetryConfig) {
+		rc.Enabled = enabled
+	}
+}
+
+// Returns a MetricHttpRetryOption that sets retry.Config.InitialInterval.
+// InitialInterval sets the time to wait after the first failure before
+// retrying.
+func MetricHttpRetryInitialInterval(interval time.Duration) MetricHttpRetryOption {
+	return func(rc *otlpmetrichttp.RetryConfig) {
+		rc.InitialInterval = interval
+	}
+}
+
+// Returns a MetricHttpRetryOption that sets retry.Config.MaxInterval.
+// MaxInterval is upper bound on the backoff interval. Once this value is
+// reached, the delay between consecutive retries will always be
+// `MaxInterval`.
+func MetricHttpRetryMaxInterval(interval time.Duration) MetricHttpRetryOption {
+	return func(rc *otlpmetrichttp.RetryConfig) {
+		rc.MaxInterval = interval
+	}
+}
+
+// Returns a MetricHttpRetryOption that sets retry.Config.MaxElapsedTime.
+// MaxElapsedTime is the maximum amount of time (including retries) spent
+// trying to send a request/batch. Once this value is reached, the data is
+// discarded.
+func MetricHttpRetryMaxElapsedTime(interval time.Duration) MetricHttpRetryOption {
+	return func(rc *otlpmetrichttp.RetryConfig) {
+		rc.MaxElapsedTime = interval
+	}
+}
@@ -0,0 +1,130 @@
+package otelutils
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// TemporalityPreference selects the aggregation temporality a MeterProvider
+// requests of its exporter for each instrument kind.
+type TemporalityPreference int
+
+const (
+	// CumulativeTemporality reports every instrument as cumulative. This is
+	// the default used by the OTel SDK.
+	CumulativeTemporality TemporalityPreference = iota
+	// DeltaTemporality reports counters (synchronous and asynchronous) and
+	// histograms as delta, leaving up-down counters and gauges as cumulative.
+	DeltaTemporality
+	// LowMemoryTemporality reports synchronous counters and histograms as
+	// delta, like DeltaTemporality, trading off cumulative tracking in the
+	// exporter for lower memory use in the SDK.
+	LowMemoryTemporality
+)
+
+// selector returns the sdkmetric.TemporalitySelector matching t.
+func (t TemporalityPreference) selector() sdkmetric.TemporalitySelector {
+	switch t {
+	case DeltaTemporality:
+		return deltaTemporality
+	case LowMemoryTemporality:
+		return lowMemoryTemporality
+	default:
+		return sdkmetric.DefaultTemporalitySelector
+	}
+}
+
+func deltaTemporality(ik sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch ik {
+	case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram, sdkmetric.InstrumentKindObservableCounter:
+		return metricdata.DeltaTemporality
+	default:
+		return metricdata.CumulativeTemporality
+	}
+}
+
+func lowMemoryTemporality(ik sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch ik {
+	case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram:
+		return metricdata.DeltaTemporality
+	default:
+		return metricdata.CumulativeTemporality
+	}
+}
+
+// temporalityExporter wraps a sdkmetric.Exporter to override the temporality
+// it reports for each instrument kind.
+type temporalityExporter struct {
+	sdkmetric.Exporter
+	selector sdkmetric.TemporalitySelector
+}
+
+func (e *temporalityExporter) Temporality(ik sdkmetric.InstrumentKind) metricdata.Temporality {
+	return e.selector(ik)
+}
+
+// MetricReaderConfig is used to configure the sdkmetric.PeriodicReader that
+// MetricsInit builds around the MetricExporterConfig it is given.
+type MetricReaderConfig struct {
+	// Sets the time interval between exports. If unset,
+	// `go.opentelemetry.io/otel/sdk/metric` uses 60 seconds as the default.
+	Interval time.Duration
+	// Sets the max amount of time the reader will wait for an export to
+	// complete. If unset, `go.opentelemetry.io/otel/sdk/metric` uses 30
+	// seconds as the default.
+	Timeout time.Duration
+	// Selects the aggregation temporality requested of the exporter for each
+	// instrument kind. Defaults to CumulativeTemporality.
+	Temporality TemporalityPreference
+}
+
+// Returns a list of sdkmetric.PeriodicReaderOption based on the values of the
+// fields in m.
+func (m MetricReaderConfig) getOptions() []sdkmetric.PeriodicReaderOption {
+	var options []sdkmetric.PeriodicReaderOption
+	if m.Interval != 0 {
+		options = append(options, sdkmetric.WithInterval(m.Interval))
+	}
+	if m.Timeout != 0 {
+		options = append(options, sdkmetric.WithTimeout(m.Timeout))
+	}
+	return options
+}
+
+// initializes the otel metrics configuration for the cli.
+func MetricsInit(startCtx context.Context, resourceConf ResourceConfig, exporterConfig MetricExporterConfig, readerConfig MetricReaderConfig) error {
+	resource, err := resourceConf.newResource(startCtx)
+	if err != nil {
+		return err
+	}
+
+	exp, err := exporterConfig.newMetricExporter(startCtx)
+	if err != nil {
+		return err
+	}
+	if readerConfig.Temporality != CumulativeTemporality {
+		exp = &temporalityExporter{Exporter: exp, selector: readerConfig.Temporality.selector()}
+	}
+
+	reader := sdkmetric.NewPeriodicReader(exp, readerConfig.getOptions()...)
+
+	global.mp = sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithResource(resource),
+	)
+	otel.SetMeterProvider(global.mp)
+
+	return nil
+}
+
+// flush and shutdown the global MeterProvider
+func MetricsEnd(endCtx context.Context) error {
+	if err := global.mp.ForceFlush(endCtx); err != nil {
+		return err
+	}
+	return global.mp.Shutdown(endCtx)
+}
@@ -0,0 +1,186 @@
+package otelutils
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Name of the envvars consulted when SamplerConfig.FromEnv is set.
+const (
+	envTracesSampler    = "OTEL_TRACES_SAMPLER"
+	envTracesSamplerArg = "OTEL_TRACES_SAMPLER_ARG"
+)
+
+// SamplerKind selects which sdkTrace.Sampler a SamplerConfig builds.
+type SamplerKind int
+
+const (
+	// ParentBasedSampler delegates the sampling decision to whichever of the
+	// ParentBased* fields matches the span's parent, falling back to Root
+	// for spans with no parent. This is the default used by
+	// `go.opentelemetry.io/otel/sdk/trace`.
+	ParentBasedSampler SamplerKind = iota
+	// AlwaysOnSampler samples every span.
+	AlwaysOnSampler
+	// AlwaysOffSampler samples no spans.
+	AlwaysOffSampler
+	// TraceIDRatioSampler samples a fraction of spans based on Ratio.
+	TraceIDRatioSampler
+)
+
+// SamplerConfig is used to configure the sdkTrace.Sampler OtelInit installs
+// on the TracerProvider it creates.
+type SamplerConfig struct {
+	// Selects which sampling strategy to build. Defaults to
+	// ParentBasedSampler.
+	Kind SamplerKind
+	// Ratio of spans to sample. Used when Kind == TraceIDRatioSampler.
+	Ratio float64
+	// Used when Kind == ParentBasedSampler to select the sampler applied to a
+	// span whose remote parent was sampled. If nil, the
+	// `go.opentelemetry.io/otel/sdk/trace` default (AlwaysOn) is used.
+	RemoteParentSampled *SamplerConfig
+	// Used when Kind == ParentBasedSampler to select the sampler applied to a
+	// span whose remote parent was not sampled. If nil, the
+	// `go.opentelemetry.io/otel/sdk/trace` default (AlwaysOff) is used.
+	RemoteParentNotSampled *SamplerConfig
+	// Used when Kind == ParentBasedSampler to select the sampler applied to a
+	// span whose local parent was sampled. If nil, the
+	// `go.opentelemetry.io/otel/sdk/trace` default (AlwaysOn) is used.
+	LocalParentSampled *SamplerConfig
+	// Used when Kind == ParentBasedSampler to select the sampler applied to a
+	// span whose local parent was not sampled. If nil, the
+	// `go.opentelemetry.io/otel/sdk/trace` default (AlwaysOff) is used.
+	LocalParentNotSampled *SamplerConfig
+	// Used when Kind == ParentBasedSampler to select the sampler applied to a
+	// span with no parent. If nil, AlwaysOnSampler is used.
+	Root *SamplerConfig
+	// If true, OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG are consulted
+	// and, if OTEL_TRACES_SAMPLER names a recognized sampler, take precedence
+	// over Kind and the other fields.
+	FromEnv bool
+}
+
+// isZero reports whether s is the zero value SamplerConfig{}, i.e. the
+// caller didn't configure anything. sdkTrace.NewTracerProvider already
+// resolves OTEL_TRACES_SAMPLER/OTEL_TRACES_SAMPLER_ARG on its own as long as
+// no WithSampler option is passed, so OtelInit uses this to decide whether to
+// pass one at all and avoid overriding that behavior for callers who never
+// opted into SamplerConfig.
+func (s SamplerConfig) isZero() bool {
+	return s == (SamplerConfig{})
+}
+
+// newSampler builds the sdkTrace.Sampler described by s.
+func (s SamplerConfig) newSampler() sdkTrace.Sampler {
+	if s.FromEnv {
+		if sampler, ok := samplerFromEnv(); ok {
+			return sampler
+		}
+	}
+
+	switch s.Kind {
+	case AlwaysOnSampler:
+		return sdkTrace.AlwaysSample()
+	case AlwaysOffSampler:
+		return sdkTrace.NeverSample()
+	case TraceIDRatioSampler:
+		return sdkTrace.TraceIDRatioBased(s.Ratio)
+	default:
+		var options []sdkTrace.ParentBasedSamplerOption
+		if s.RemoteParentSampled != nil {
+			options = append(options, sdkTrace.WithRemoteParentSampled(s.RemoteParentSampled.newSampler()))
+		}
+		if s.RemoteParentNotSampled != nil {
+			options = append(options, sdkTrace.WithRemoteParentNotSampled(s.RemoteParentNotSampled.newSampler()))
+		}
+		if s.LocalParentSampled != nil {
+			options = append(options, sdkTrace.WithLocalParentSampled(s.LocalParentSampled.newSampler()))
+		}
+		if s.LocalParentNotSampled != nil {
+			options = append(options, sdkTrace.WithLocalParentNotSampled(s.LocalParentNotSampled.newSampler()))
+		}
+		root := sdkTrace.AlwaysSample()
+		if s.Root != nil {
+			root = s.Root.newSampler()
+		}
+		return sdkTrace.ParentBased(root, options...)
+	}
+}
+
+// samplerFromEnv builds the sdkTrace.Sampler named by OTEL_TRACES_SAMPLER,
+// using OTEL_TRACES_SAMPLER_ARG as its ratio where applicable. ok is false if
+// OTEL_TRACES_SAMPLER is unset or names a sampler this package does not
+// support.
+func samplerFromEnv() (sampler sdkTrace.Sampler, ok bool) {
+	name := os.Getenv(envTracesSampler)
+	if name == "" {
+		return nil, false
+	}
+
+	ratio := 1.0
+	if arg := os.Getenv(envTracesSamplerArg); arg != "" {
+		if parsed, err := strconv.ParseFloat(arg, 64); err == nil {
+			ratio = parsed
+		}
+	}
+
+	switch name {
+	case "always_on":
+		return sdkTrace.AlwaysSample(), true
+	case "always_off":
+		return sdkTrace.NeverSample(), true
+	case "traceidratio":
+		return sdkTrace.TraceIDRatioBased(ratio), true
+	case "parentbased_always_on":
+		return sdkTrace.ParentBased(sdkTrace.AlwaysSample()), true
+	case "parentbased_always_off":
+		return sdkTrace.ParentBased(sdkTrace.NeverSample()), true
+	case "parentbased_traceidratio":
+		return sdkTrace.ParentBased(sdkTrace.TraceIDRatioBased(ratio)), true
+	default:
+		return nil, false
+	}
+}
+
+// BatchConfig is used to configure the sdkTrace.BatchSpanProcessor OtelInit
+// builds to batch spans to the configured SpanExporterConfig.
+type BatchConfig struct {
+	// Sets the maximum queue size used to buffer spans for delayed
+	// processing. If unset, `go.opentelemetry.io/otel/sdk/trace` uses 2048 as
+	// the default.
+	MaxQueueSize int
+	// Sets the delay interval between two consecutive batch exports. If
+	// unset, `go.opentelemetry.io/otel/sdk/trace` uses 5 seconds as the
+	// default.
+	BatchTimeout time.Duration
+	// Sets the maximum number of spans to include in a single export batch.
+	// If unset, `go.opentelemetry.io/otel/sdk/trace` uses 512 as the default.
+	MaxExportBatchSize int
+	// Sets the maximum amount of time an export is allowed to run before it
+	// is canceled. If unset, `go.opentelemetry.io/otel/sdk/trace` uses 30
+	// seconds as the default.
+	ExportTimeout time.Duration
+}
+
+// Returns a list of sdkTrace.BatchSpanProcessorOption based on the values of
+// the fields in b.
+func (b BatchConfig) getOptions() []sdkTrace.BatchSpanProcessorOption {
+	var options []sdkTrace.BatchSpanProcessorOption
+	if b.MaxQueueSize != 0 {
+		options = append(options, sdkTrace.WithMaxQueueSize(b.MaxQueueSize))
+	}
+	if b.BatchTimeout != 0 {
+		options = append(options, sdkTrace.WithBatchTimeout(b.BatchTimeout))
+	}
+	if b.MaxExportBatchSize != 0 {
+		options = append(options, sdkTrace.WithMaxExportBatchSize(b.MaxExportBatchSize))
+	}
+	if b.ExportTimeout != 0 {
+		options = append(options, sdkTrace.WithExportTimeout(b.ExportTimeout))
+	}
+	return options
+}
@@ -0,0 +1,80 @@
+package otelutils
+
+import (
+	"testing"
+
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSamplerFromEnv(t *testing.T) {
+	tests := map[string]struct {
+		sampler string
+		arg     string
+		wantOk  bool
+		want    sdkTrace.Sampler
+	}{
+		"unset": {
+			sampler: "",
+			wantOk:  false,
+		},
+		"unrecognized": {
+			sampler: "xray",
+			wantOk:  false,
+		},
+		"always_on": {
+			sampler: "always_on",
+			wantOk:  true,
+			want:    sdkTrace.AlwaysSample(),
+		},
+		"always_off": {
+			sampler: "always_off",
+			wantOk:  true,
+			want:    sdkTrace.NeverSample(),
+		},
+		"traceidratio": {
+			sampler: "traceidratio",
+			arg:     "0.25",
+			wantOk:  true,
+			want:    sdkTrace.TraceIDRatioBased(0.25),
+		},
+		"parentbased_always_on": {
+			sampler: "parentbased_always_on",
+			wantOk:  true,
+			want:    sdkTrace.ParentBased(sdkTrace.AlwaysSample()),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.sampler == "" {
+				t.Setenv(envTracesSampler, "")
+			} else {
+				t.Setenv(envTracesSampler, tt.sampler)
+			}
+			t.Setenv(envTracesSamplerArg, tt.arg)
+
+			got, ok := samplerFromEnv()
+			if ok != tt.wantOk {
+				t.Fatalf("samplerFromEnv() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if got.Description() != tt.want.Description() {
+				t.Errorf("samplerFromEnv() = %v, want %v", got.Description(), tt.want.Description())
+			}
+		})
+	}
+}
+
+func TestSamplerConfigIsZero(t *testing.T) {
+	if !(SamplerConfig{}).isZero() {
+		t.Error("SamplerConfig{}.isZero() = false, want true")
+	}
+	if (SamplerConfig{FromEnv: true}).isZero() {
+		t.Error("SamplerConfig{FromEnv: true}.isZero() = true, want false")
+	}
+	if (SamplerConfig{Kind: AlwaysOnSampler}).isZero() {
+		t.Error("SamplerConfig{Kind: AlwaysOnSampler}.isZero() = true, want false")
+	}
+}